@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// LevelOverridesHandler serves the runtime per-subsystem log level overrides: GET returns the
+// current overrides as JSON, POST (with a JSON body {"subsystem": "...", "level": "..."}) calls
+// SetLevel, so operators can quiet a noisy subsystem or turn on DEBUG for one handler without
+// redeploying.
+//
+// This package does not own an HTTP route table, so it cannot mount this handler itself: the
+// app/router package that registers the framework's built-in admin routes is not part of this
+// checkout. Callers must mount the returned http.Handler under their own admin mux (e.g.
+// mux.Handle("/admin/log-level", logger.LevelOverridesHandler())) until that wiring lands.
+func (l *logger) LevelOverridesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(l.levels.snapshot())
+		case http.MethodPost:
+			l.handleSetLevel(w, r)
+		default:
+			w.Header().Set("Allow", http.MethodGet+", "+http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (l *logger) handleSetLevel(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Subsystem string `json:"subsystem"`
+		Level     string `json:"level"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	subsystem := strings.TrimSpace(body.Subsystem)
+	if subsystem == "" {
+		http.Error(w, "subsystem is required", http.StatusBadRequest)
+		return
+	}
+
+	lvl, ok := parseLevel(body.Level)
+	if !ok {
+		http.Error(w, "invalid level: "+body.Level, http.StatusBadRequest)
+		return
+	}
+
+	l.SetLevel(subsystem, lvl)
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ansiSGR matches the SGR escape sequences (e.g. "\x1b[38;5;202m") this package emits.
+var ansiSGR = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// shouldColorize decides whether PrettyFormatter should emit ANSI SGR escapes. LOG_COLOR takes
+// precedence when set to "always"/"never"; otherwise NO_COLOR disables and FORCE_COLOR enables
+// color regardless of isTerminal, matching the conventions most CLIs and CI systems already honor.
+func shouldColorize(isTerminal bool) bool {
+	switch strings.ToLower(os.Getenv("LOG_COLOR")) {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	if _, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		return true
+	}
+
+	return isTerminal
+}
+
+// stripANSI removes SGR escape sequences, used to give PrettyFormatter's non-colored path the
+// same layout as the colored one without a second, drifting copy of every Fprintf call.
+func stripANSI(s string) string {
+	return ansiSGR.ReplaceAllString(s, "")
+}
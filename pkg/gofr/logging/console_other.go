@@ -0,0 +1,11 @@
+//go:build !windows
+
+package logging
+
+import "os"
+
+// enableVirtualTerminal is a no-op on platforms whose terminals already interpret ANSI SGR
+// escapes natively; it exists only so NewLogger can call it unconditionally.
+func enableVirtualTerminal(_ *os.File) bool {
+	return true
+}
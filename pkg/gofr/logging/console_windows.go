@@ -0,0 +1,26 @@
+//go:build windows
+
+package logging
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal turns on ENABLE_VIRTUAL_TERMINAL_PROCESSING for f's console handle, so
+// legacy Windows consoles (cmd.exe, older PowerShell) render ANSI SGR escapes instead of garbling
+// them. It is a no-op returning false when f is not a console, e.g. when output is redirected.
+func enableVirtualTerminal(f *os.File) bool {
+	handle := windows.Handle(f.Fd())
+
+	var mode uint32
+
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+
+	mode |= windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING
+
+	return windows.SetConsoleMode(handle, mode) == nil
+}
@@ -0,0 +1,161 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// fileWriter is an io.Writer backed by a path on disk. It transparently reopens the underlying
+// *os.File on SIGHUP, or when it notices the path no longer points at the file it holds (e.g.
+// logrotate renamed it out from under the process), so log lines are never dropped mid-rotation.
+type fileWriter struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+
+	sigCh  chan os.Signal
+	doneCh chan struct{}
+}
+
+func newFileWriter(path string) (*fileWriter, error) {
+	f, err := openLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &fileWriter{
+		path:   path,
+		file:   f,
+		sigCh:  make(chan os.Signal, 1),
+		doneCh: make(chan struct{}),
+	}
+
+	signal.Notify(w.sigCh, syscall.SIGHUP)
+
+	go w.superviseReopen()
+
+	return w, nil
+}
+
+func openLogFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+}
+
+func (w *fileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.file.Write(p)
+}
+
+// superviseReopen is the background goroutine that swaps in a freshly opened file whenever SIGHUP
+// arrives, or whenever a periodic check notices the path was rotated by external tooling.
+func (w *fileWriter) superviseReopen() {
+	const rotationCheckInterval = 5 * time.Second
+
+	ticker := time.NewTicker(rotationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.sigCh:
+			w.reopen()
+		case <-ticker.C:
+			if w.rotated() {
+				w.reopen()
+			}
+		case <-w.doneCh:
+			return
+		}
+	}
+}
+
+// rotated reports whether path no longer refers to the *os.File this writer currently holds.
+func (w *fileWriter) rotated() bool {
+	pathInfo, err := os.Stat(w.path)
+	if err != nil {
+		return false
+	}
+
+	w.mu.Lock()
+	fileInfo, err := w.file.Stat()
+	w.mu.Unlock()
+
+	if err != nil {
+		return true
+	}
+
+	return !os.SameFile(pathInfo, fileInfo)
+}
+
+func (w *fileWriter) reopen() {
+	f, err := openLogFile(w.path)
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	old := w.file
+	w.file = f
+	w.mu.Unlock()
+
+	_ = old.Close()
+}
+
+// Close stops the reopen supervisor and closes the underlying file.
+func (w *fileWriter) Close() error {
+	signal.Stop(w.sigCh)
+	close(w.doneCh)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.file.Close()
+}
+
+// NewFileLogger returns a Logger that writes JSON entries to path, reopening the file on SIGHUP
+// or when path is rotated out from under it, so a long-lived Gofr service can sit behind
+// logrotate/newsyslog without dropping log lines or needing a restart to pick up the new file.
+func NewFileLogger(path string, level Level, opts ...Option) (Logger, error) {
+	w, err := newFileWriter(path)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &logger{
+		level:            level,
+		normalOut:        w,
+		errorOut:         w,
+		formatter:        JSONFormatter{},
+		levels:           newLevelOverrides(),
+		hooks:            newHookRegistry(),
+		gracefulShutdown: true,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	l.pipe = newPipeline(l.queueSize, l.overflowPolicy, l.gracefulShutdown, l)
+
+	return l, nil
+}
+
+// Close flushes the async log pipeline and then, for a Logger backed by NewFileLogger, closes
+// the underlying file and stops its reopen supervisor. For Loggers that don't own a resource
+// like that (e.g. one returned by NewLogger) it only flushes the pipeline. Either way the
+// pipeline stays shut afterwards, so later log calls are dropped.
+func (l *logger) Close() error {
+	_ = l.Flush(context.Background())
+
+	if fw, ok := l.normalOut.(*fileWriter); ok {
+		return fw.Close()
+	}
+
+	return nil
+}
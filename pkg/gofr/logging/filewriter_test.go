@@ -0,0 +1,114 @@
+//go:build !windows
+
+// This file exercises syscall.Kill(SIGHUP) and rename-while-open rotation semantics, neither of
+// which exist on Windows; see console_windows.go/console_other.go for the same split elsewhere
+// in this package.
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestFileWriter_WritesToPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	w, err := newFileWriter(path)
+	if err != nil {
+		t.Fatalf("newFileWriter: %v", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	if _, err := w.Write([]byte("first line\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	if string(got) != "first line\n" {
+		t.Errorf("file contents = %q, want %q", got, "first line\n")
+	}
+}
+
+func TestFileWriter_ReopensOnSIGHUP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	w, err := newFileWriter(path)
+	if err != nil {
+		t.Fatalf("newFileWriter: %v", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	if _, err := w.Write([]byte("before\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	rotated := path + ".1"
+	if err := os.Rename(path, rotated); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("Kill(SIGHUP): %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		// rotated() turns false only once the reopened file has been swapped in, not merely created.
+		if _, err := os.Stat(path); err == nil && !w.rotated() {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, err := w.Write([]byte("after\n")); err != nil {
+		t.Fatalf("Write after SIGHUP: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile new path: %v", err)
+	}
+
+	if string(got) != "after\n" {
+		t.Errorf("new file contents = %q, want %q", got, "after\n")
+	}
+
+	oldContents, err := os.ReadFile(rotated)
+	if err != nil {
+		t.Fatalf("ReadFile rotated path: %v", err)
+	}
+
+	if string(oldContents) != "before\n" {
+		t.Errorf("rotated file contents = %q, want %q", oldContents, "before\n")
+	}
+}
+
+func TestFileWriter_Rotated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	w, err := newFileWriter(path)
+	if err != nil {
+		t.Fatalf("newFileWriter: %v", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	if w.rotated() {
+		t.Error("freshly opened fileWriter should not report itself as rotated")
+	}
+
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+
+	if !w.rotated() {
+		t.Error("fileWriter should report itself as rotated once the path was renamed out from under it")
+	}
+}
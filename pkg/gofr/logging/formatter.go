@@ -0,0 +1,189 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gofr.dev/pkg/gofr/datasource/redis"
+	"gofr.dev/pkg/gofr/datasource/sql"
+	"gofr.dev/pkg/gofr/http/middleware"
+	"gofr.dev/pkg/gofr/service"
+)
+
+// Formatter renders a log Entry onto out. Implementations must be safe to reuse across log calls;
+// the logger does not guard formatter calls with a lock of its own.
+type Formatter interface {
+	Format(e Entry, out io.Writer)
+}
+
+// JSONFormatter writes each Entry as a single line of JSON, with any fields added via
+// Logger.WithFields serialized as top-level keys alongside Level, time and message.
+type JSONFormatter struct{}
+
+func (JSONFormatter) Format(e Entry, out io.Writer) {
+	_ = json.NewEncoder(out).Encode(e)
+}
+
+// TextFormatter writes a human-readable, uncolored line per Entry. It suits non-terminal output
+// read by people, such as files and CI logs, where ANSI escapes would just be noise; it is
+// selected with LOG_FORMATTER=text.
+type TextFormatter struct{}
+
+func (TextFormatter) Format(e Entry, out io.Writer) {
+	fmt.Fprintf(out, "%s [%s] %v%s\n", e.Level.String()[0:4], e.Time.Format("15:04:05"), e.Message, formatFieldsSuffix(e.Fields))
+}
+
+// LogfmtFormatter writes each Entry as space-separated key=value pairs, the format expected by
+// tools such as Loki, Prometheus' promtail, and heroku-style log drains.
+type LogfmtFormatter struct{}
+
+func (LogfmtFormatter) Format(e Entry, out io.Writer) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "level=%s time=%q message=%q", strings.ToLower(e.Level.String()), e.Time.Format("15:04:05"), fmt.Sprint(e.Message))
+
+	for _, k := range sortedKeys(e.Fields) {
+		fmt.Fprintf(&b, " %s=%q", k, fmt.Sprint(e.Fields[k]))
+	}
+
+	b.WriteByte('\n')
+
+	_, _ = io.WriteString(out, b.String())
+}
+
+// PrettyFormatter is the colored, human-friendly formatter used on interactive terminals. It gives
+// special treatment to the framework's own request/query logs, recognising them by message type.
+// When Color is false the same layout is rendered with the ANSI SGR escapes stripped, which is
+// what NewLogger picks on Windows consoles without virtual terminal support and in
+// NO_COLOR/LOG_COLOR=never environments. Non-terminal output such as CI pipes gets JSONFormatter
+// unless LOG_FORMATTER=pretty is set.
+type PrettyFormatter struct {
+	Color bool
+}
+
+func (f PrettyFormatter) Format(e Entry, out io.Writer) {
+	if f.Color {
+		f.render(e, out)
+		return
+	}
+
+	var b strings.Builder
+
+	f.render(e, &b)
+
+	_, _ = io.WriteString(out, stripANSI(b.String()))
+}
+
+func (PrettyFormatter) render(e Entry, out io.Writer) {
+	switch msg := e.Message.(type) {
+	case middleware.RequestLog:
+		fmt.Fprintf(out, "[38;5;%dm%s[0m [%s] [38;5;8m%s [38;5;%dm%d[0m "+
+			"%8d[38;5;8mµs[0m %s %s \n", e.Level.color(), e.Level.String()[0:4],
+			e.Time.Format("15:04:05"), msg.ID, colorForStatusCode(msg.Response), msg.Response, msg.ResponseTime, msg.Method, msg.URI)
+	case sql.Log:
+		fmt.Fprintf(out, "[38;5;%dm%s[0m [%s] [38;5;8m%-32s [38;5;24m%s[0m %8d[38;5;8mµs[0m   %v\n",
+			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), msg.Type, "SQL", msg.Duration, msg.Query)
+	case redis.QueryLog:
+		printRedisQueryLog(e, msg, out)
+	case service.Log:
+		fmt.Fprintf(out, "[38;5;%dm%s[0m [%s] [38;5;8m%s [38;5;%dm%d[0m %8d[38;5;8mµs[0m %s %s \n",
+			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), msg.CorrelationID, colorForStatusCode(msg.ResponseCode),
+			msg.ResponseCode, msg.ResponseTime, msg.HTTPMethod, msg.URI)
+	case service.ErrorLog:
+		fmt.Fprintf(out, "[38;5;%dm%s[0m [%s] [38;5;8m%s "+
+			"[38;5;%dm%d[0m %8d[38;5;8mµs[0m %s %s \033[0;31m %s \n",
+			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), msg.CorrelationID, colorForStatusCode(msg.ResponseCode),
+			msg.ResponseCode, msg.ResponseTime, msg.HTTPMethod, msg.URI, msg.ErrorMessage)
+	default:
+		fmt.Fprintf(out, "[38;5;%dm%s[0m [%s] %v%s\n", e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"),
+			e.Message, formatFieldsSuffix(e.Fields))
+	}
+}
+
+// colorForStatusCode provide color for the status code in the terminal when logs is being pretty-printed.
+func colorForStatusCode(status int) int {
+	const (
+		blue   = 34
+		red    = 202
+		yellow = 220
+	)
+
+	switch {
+	case status >= 200 && status < 300:
+		return blue
+	case status >= 400 && status < 500:
+		return yellow
+	case status >= 500 && status < 600:
+		return red
+	}
+
+	return 0
+}
+
+// printRedisQueryLog formats and prints the log entry for Redis queries.
+func printRedisQueryLog(e Entry, msg redis.QueryLog, out io.Writer) {
+	args := msg.Args.([]interface{})
+	strArgs := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		strArgs = append(strArgs, fmt.Sprint(arg))
+	}
+
+	// Formatting and printing the log entry based on the Redis query type.
+	switch msg.Query {
+	case "pipeline":
+		fmt.Fprintf(out, "[38;5;%dm%s[0m [%s] [38;5;8m%-32s [38;5;24m%s[0m %8d[38;5;8mµs[0m %s\n",
+			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), msg.Query, "REDIS", msg.Duration, strArgs[0][1:len(strArgs[0])-1])
+	default:
+		fmt.Fprintf(out, "[38;5;%dm%s[0m [%s] [38;5;8m%-32s [38;5;24m%s[0m %8d[38;5;8mµs[0m %v\n",
+			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), strArgs[0], "REDIS", msg.Duration, strings.Join(strArgs, " "))
+	}
+}
+
+// formatFieldsSuffix renders WithFields context as a trailing " key=value" list, for the
+// non-JSON formatters where there is no top-level object to merge the fields into.
+func formatFieldsSuffix(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for _, k := range sortedKeys(fields) {
+		fmt.Fprintf(&b, " %s=%v", k, fields[k])
+	}
+
+	return b.String()
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// formatterFromEnv selects a Formatter based on the LOG_FORMATTER environment variable
+// (json, text, logfmt or pretty). When unset or unrecognized, it falls back to defaultFormatter.
+func formatterFromEnv(defaultFormatter Formatter, isTerminal bool) Formatter {
+	switch strings.ToLower(os.Getenv("LOG_FORMATTER")) {
+	case "json":
+		return JSONFormatter{}
+	case "text":
+		return TextFormatter{}
+	case "logfmt":
+		return LogfmtFormatter{}
+	case "pretty":
+		return PrettyFormatter{Color: shouldColorize(isTerminal)}
+	default:
+		return defaultFormatter
+	}
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEntry() Entry {
+	return Entry{
+		Level:   INFO,
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message: "hello",
+		Fields:  map[string]interface{}{"traceID": "abc123"},
+	}
+}
+
+func TestJSONFormatter_Format(t *testing.T) {
+	var buf bytes.Buffer
+
+	JSONFormatter{}.Format(testEntry(), &buf)
+
+	out := buf.String()
+	for _, want := range []string{`"message":"hello"`, `"traceID":"abc123"`, `"time":`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("JSONFormatter output %q does not contain %q", out, want)
+		}
+	}
+}
+
+func TestTextFormatter_Format(t *testing.T) {
+	var buf bytes.Buffer
+
+	TextFormatter{}.Format(testEntry(), &buf)
+
+	out := buf.String()
+	if strings.ContainsAny(out, "\x1b") {
+		t.Errorf("TextFormatter output %q should not contain ANSI escapes", out)
+	}
+
+	for _, want := range []string{"hello", "traceID=abc123"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("TextFormatter output %q does not contain %q", out, want)
+		}
+	}
+}
+
+func TestLogfmtFormatter_Format(t *testing.T) {
+	var buf bytes.Buffer
+
+	LogfmtFormatter{}.Format(testEntry(), &buf)
+
+	out := buf.String()
+	for _, want := range []string{`level=info`, `message="hello"`, `traceID="abc123"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("LogfmtFormatter output %q does not contain %q", out, want)
+		}
+	}
+}
+
+func TestPrettyFormatter_ColorToggle(t *testing.T) {
+	entry := testEntry()
+
+	var colored bytes.Buffer
+
+	PrettyFormatter{Color: true}.Format(entry, &colored)
+
+	if !strings.Contains(colored.String(), "\x1b[") {
+		t.Errorf("PrettyFormatter{Color: true} output %q should contain ANSI escapes", colored.String())
+	}
+
+	var plain bytes.Buffer
+
+	PrettyFormatter{Color: false}.Format(entry, &plain)
+
+	if strings.ContainsAny(plain.String(), "\x1b") {
+		t.Errorf("PrettyFormatter{Color: false} output %q should not contain ANSI escapes", plain.String())
+	}
+
+	if !strings.Contains(plain.String(), "hello") {
+		t.Errorf("PrettyFormatter{Color: false} output %q should still contain the message", plain.String())
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"no escapes", "plain text", "plain text"},
+		{"single escape", "\x1b[38;5;202mred\x1b[0m", "red"},
+		{"multiple escapes", "\x1b[1m\x1b[38;5;8mvalue\x1b[0m", "value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripANSI(tt.in); got != tt.want {
+				t.Errorf("stripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package logging
+
+import "sync"
+
+// Hook lets callers observe every log Entry whose Level is one of its declared Levels, without
+// patching the framework. Typical uses are shipping entries to Sentry, syslog, or a file-rotator.
+type Hook interface {
+	// Levels returns the Levels this Hook wants to be fired for.
+	Levels() []Level
+	// Fire is called for each matching Entry on the logger's background drain goroutine, so a
+	// slow Hook adds latency to the queue, not to the caller that produced the log line.
+	Fire(e Entry) error
+}
+
+// hookRegistry guards the hook list against AddHook being called (from any goroutine, at any
+// point in a Logger's lifetime) concurrently with fireHooks ranging over it on the drain
+// goroutine. It is shared by pointer across WithFields clones, same as levelOverrides and
+// pipeline, so a hook registered on one clone fires for entries logged through any of them.
+type hookRegistry struct {
+	mu    sync.RWMutex
+	hooks []Hook
+}
+
+func newHookRegistry() *hookRegistry {
+	return &hookRegistry{}
+}
+
+func (r *hookRegistry) add(hook Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hooks = append(r.hooks, hook)
+}
+
+// snapshot returns a copy of the registered hooks, safe to range over without holding the lock
+// for the duration of each Hook.Fire call.
+func (r *hookRegistry) snapshot() []Hook {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hooks := make([]Hook, len(r.hooks))
+	copy(hooks, r.hooks)
+
+	return hooks
+}
+
+func (l *logger) AddHook(hook Hook) {
+	l.hooks.add(hook)
+}
+
+func (l *logger) fireHooks(e Entry) {
+	for _, hook := range l.hooks.snapshot() {
+		for _, lvl := range hook.Levels() {
+			if lvl == e.Level {
+				_ = hook.Fire(e)
+
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// recordingHook captures every Entry it's fired for, guarded by a mutex since Fire runs on the
+// pipeline's drain goroutine concurrently with the test goroutine's assertions.
+type recordingHook struct {
+	levels []Level
+
+	mu      sync.Mutex
+	entries []Entry
+}
+
+func (h *recordingHook) Levels() []Level { return h.levels }
+
+func (h *recordingHook) Fire(e Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, e)
+
+	return nil
+}
+
+func (h *recordingHook) fired() []Entry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make([]Entry, len(h.entries))
+	copy(out, h.entries)
+
+	return out
+}
+
+func TestAddHook_OnlyFiresForMatchingLevels(t *testing.T) {
+	l, _ := newTestLogger(8, Block)
+
+	hook := &recordingHook{levels: []Level{ERROR}}
+	l.AddHook(hook)
+
+	l.Info("ignored")
+	l.Error("reported")
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	entries := hook.fired()
+	if len(entries) != 1 {
+		t.Fatalf("got %d fired entries, want 1", len(entries))
+	}
+
+	if entries[0].Level != ERROR {
+		t.Errorf("fired entry Level = %v, want %v", entries[0].Level, ERROR)
+	}
+}
+
+func TestAddHook_MultipleHooksAllFire(t *testing.T) {
+	l, _ := newTestLogger(8, Block)
+
+	first := &recordingHook{levels: []Level{INFO}}
+	second := &recordingHook{levels: []Level{INFO}}
+
+	l.AddHook(first)
+	l.AddHook(second)
+
+	l.Info("broadcast")
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if len(first.fired()) != 1 || len(second.fired()) != 1 {
+		t.Errorf("expected both hooks to fire once, got %d and %d", len(first.fired()), len(second.fired()))
+	}
+}
+
+// errHook's Fire always errors, to confirm fireHooks doesn't let one hook's failure stop the
+// others or the drain goroutine from running.
+type errHook struct{ levels []Level }
+
+func (h errHook) Levels() []Level { return h.levels }
+
+func (h errHook) Fire(Entry) error {
+	return errors.New("boom")
+}
+
+func TestAddHook_ErroringHookDoesNotBlockOthers(t *testing.T) {
+	l, cf := newTestLogger(8, Block)
+
+	l.AddHook(errHook{levels: []Level{INFO}})
+
+	other := &recordingHook{levels: []Level{INFO}}
+	l.AddHook(other)
+
+	l.Info("still delivered")
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if len(other.fired()) != 1 {
+		t.Errorf("second hook should still fire after the first one errors, got %d", len(other.fired()))
+	}
+
+	if len(cf.msgs) != 1 {
+		t.Errorf("formatter should still receive the entry, got %d messages", len(cf.msgs))
+	}
+}
+
+func TestAddHook_ConcurrentRegistrationAndLoggingDoesNotRace(t *testing.T) {
+	l, _ := newTestLogger(8, Block)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			l.AddHook(&recordingHook{levels: []Level{INFO}})
+		}(i)
+
+		go func(i int) {
+			defer wg.Done()
+			l.Info(i)
+		}(i)
+	}
+
+	wg.Wait()
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+}
+
+func TestWithFields_MergesAndOverrides(t *testing.T) {
+	l, _ := newTestLogger(8, Block)
+
+	base := l.WithFields(map[string]interface{}{"service": "checkout", "region": "us-east"})
+	child := base.WithFields(map[string]interface{}{"region": "eu-west", "traceID": "abc123"})
+
+	child.Info("hello")
+
+	if err := child.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	concrete, ok := child.(*logger)
+	if !ok {
+		t.Fatalf("WithFields did not return a *logger")
+	}
+
+	want := map[string]interface{}{"service": "checkout", "region": "eu-west", "traceID": "abc123"}
+
+	for k, v := range want {
+		if got := concrete.fields[k]; got != v {
+			t.Errorf("fields[%q] = %v, want %v", k, got, v)
+		}
+	}
+
+	if len(concrete.fields) != len(want) {
+		t.Errorf("got %d fields, want %d", len(concrete.fields), len(want))
+	}
+
+	baseConcrete, ok := base.(*logger)
+	if !ok {
+		t.Fatalf("WithFields did not return a *logger")
+	}
+
+	if baseConcrete.fields["region"] != "us-east" {
+		t.Error("WithFields must not mutate the parent's fields map")
+	}
+}
+
+func TestWithFields_SharesPipelineAndHooksWithParent(t *testing.T) {
+	l, _ := newTestLogger(8, Block)
+
+	hook := &recordingHook{levels: []Level{INFO}}
+	l.AddHook(hook)
+
+	child := l.WithFields(map[string]interface{}{"traceID": "xyz"})
+	child.Info("from child")
+
+	if err := child.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if len(hook.fired()) != 1 {
+		t.Errorf("a hook registered on the parent should fire for entries logged through a WithFields clone, got %d", len(hook.fired()))
+	}
+}
@@ -0,0 +1,129 @@
+package logging
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"gofr.dev/pkg/gofr/datasource/redis"
+	"gofr.dev/pkg/gofr/datasource/sql"
+	"gofr.dev/pkg/gofr/http/middleware"
+	"gofr.dev/pkg/gofr/service"
+)
+
+// levelOverrides is the mutable, per-subsystem log-level table shared (by pointer) across every
+// Logger derived from the same NewLogger/NewFileLogger call via WithFields, so SetLevel takes
+// effect everywhere that Logger is held.
+type levelOverrides struct {
+	mu     sync.RWMutex
+	levels map[string]Level
+}
+
+// newLevelOverrides seeds the table from LOG_LEVEL_OVERRIDES, a comma-separated subsystem=level
+// list such as "sql=DEBUG,redis=INFO,http=WARN". Entries that don't parse are skipped.
+func newLevelOverrides() *levelOverrides {
+	lo := &levelOverrides{levels: make(map[string]Level)}
+
+	raw := os.Getenv("LOG_LEVEL_OVERRIDES")
+	if raw == "" {
+		return lo
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		subsystem, levelName, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+
+		lvl, ok := parseLevel(strings.TrimSpace(levelName))
+		if !ok {
+			continue
+		}
+
+		lo.levels[strings.TrimSpace(subsystem)] = lvl
+	}
+
+	return lo
+}
+
+func (lo *levelOverrides) get(subsystem string) (Level, bool) {
+	lo.mu.RLock()
+	defer lo.mu.RUnlock()
+
+	lvl, ok := lo.levels[subsystem]
+
+	return lvl, ok
+}
+
+func (lo *levelOverrides) set(subsystem string, lvl Level) {
+	lo.mu.Lock()
+	defer lo.mu.Unlock()
+
+	lo.levels[subsystem] = lvl
+}
+
+// snapshot returns a copy of the current overrides, safe for a caller to range over or marshal.
+func (lo *levelOverrides) snapshot() map[string]Level {
+	lo.mu.RLock()
+	defer lo.mu.RUnlock()
+
+	out := make(map[string]Level, len(lo.levels))
+	for k, v := range lo.levels {
+		out[k] = v
+	}
+
+	return out
+}
+
+// parseLevel parses the level names accepted by LOG_LEVEL_OVERRIDES and SetLevel, case-insensitively.
+func parseLevel(s string) (Level, bool) {
+	switch strings.ToUpper(s) {
+	case "DEBUG":
+		return DEBUG, true
+	case "INFO":
+		return INFO, true
+	case "WARN", "WARNING":
+		return WARN, true
+	case "ERROR":
+		return ERROR, true
+	default:
+		return 0, false
+	}
+}
+
+// subsystemOf maps a log Entry's Message to the subsystem name used by LOG_LEVEL_OVERRIDES/
+// SetLevel, mirroring the same type switch the formatters use to give these messages special
+// treatment. Messages that aren't one of the framework's own log types have no subsystem.
+func subsystemOf(msg interface{}) (string, bool) {
+	switch msg.(type) {
+	case middleware.RequestLog:
+		return "http", true
+	case sql.Log:
+		return "sql", true
+	case redis.QueryLog:
+		return "redis", true
+	case service.Log, service.ErrorLog:
+		return "service", true
+	default:
+		return "", false
+	}
+}
+
+// SetLevel overrides the log level for a single subsystem (sql, redis, http, service) at
+// runtime, so a user's own handlers needn't drown in framework chatter, or lose signal from one
+// noisy subsystem, just to get useful output from the rest.
+func (l *logger) SetLevel(subsystem string, lvl Level) {
+	l.levels.set(subsystem, lvl)
+}
+
+// thresholdFor returns the effective minimum Level for an Entry: its subsystem's override, if
+// one is set, otherwise the Logger's global level.
+func (l *logger) thresholdFor(msg interface{}) Level {
+	if subsystem, ok := subsystemOf(msg); ok {
+		if lvl, ok := l.levels.get(subsystem); ok {
+			return lvl
+		}
+	}
+
+	return l.level
+}
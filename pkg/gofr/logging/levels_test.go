@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"gofr.dev/pkg/gofr/datasource/sql"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Level
+		wantOK bool
+	}{
+		{"DEBUG", DEBUG, true},
+		{"debug", DEBUG, true},
+		{"INFO", INFO, true},
+		{"WARN", WARN, true},
+		{"warning", WARN, true},
+		{"ERROR", ERROR, true},
+		{"nonsense", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseLevel(tt.in)
+		if ok != tt.wantOK {
+			t.Errorf("parseLevel(%q) ok = %v, want %v", tt.in, ok, tt.wantOK)
+			continue
+		}
+
+		if ok && got != tt.want {
+			t.Errorf("parseLevel(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLevelOverrides_EnvParsing(t *testing.T) {
+	t.Setenv("LOG_LEVEL_OVERRIDES", "sql=DEBUG, redis=INFO ,http=WARN,garbage=NOPE")
+
+	lo := newLevelOverrides()
+
+	tests := []struct {
+		subsystem string
+		want      Level
+	}{
+		{"sql", DEBUG},
+		{"redis", INFO},
+		{"http", WARN},
+	}
+
+	for _, tt := range tests {
+		got, ok := lo.get(tt.subsystem)
+		if !ok {
+			t.Errorf("expected an override for %q", tt.subsystem)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("override for %q = %v, want %v", tt.subsystem, got, tt.want)
+		}
+	}
+
+	if _, ok := lo.get("garbage"); ok {
+		t.Error("an override with an unparseable level should be skipped, not stored")
+	}
+}
+
+func TestSubsystemOf(t *testing.T) {
+	if got, ok := subsystemOf(sql.Log{}); !ok || got != "sql" {
+		t.Errorf("subsystemOf(sql.Log{}) = (%q, %v), want (\"sql\", true)", got, ok)
+	}
+
+	if _, ok := subsystemOf("plain string"); ok {
+		t.Error("subsystemOf of an unrecognized message type should report false")
+	}
+}
+
+func TestLogger_SetLevelOverridesSubsystemThreshold(t *testing.T) {
+	os.Unsetenv("LOG_LEVEL_OVERRIDES")
+
+	l, cf := newTestLogger(16, Block)
+	l.level = WARN
+
+	l.Debug(sql.Log{})
+
+	l.SetLevel("sql", DEBUG)
+
+	l.Debug(sql.Log{})
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if len(cf.msgs) != 1 {
+		t.Errorf("got %d delivered entries, want exactly 1 (before SetLevel should be dropped)", len(cf.msgs))
+	}
+}
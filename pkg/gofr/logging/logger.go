@@ -1,19 +1,15 @@
 package logging
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
-	"strings"
 	"time"
 
 	"golang.org/x/term"
-
-	"gofr.dev/pkg/gofr/datasource/redis"
-	"gofr.dev/pkg/gofr/datasource/sql"
-	"gofr.dev/pkg/gofr/http/middleware"
-	"gofr.dev/pkg/gofr/service"
 )
 
 type Logger interface {
@@ -25,6 +21,27 @@ type Logger interface {
 	Infof(format string, args ...interface{})
 	Error(args ...interface{})
 	Errorf(format string, args ...interface{})
+
+	// WithFields returns a Logger that attaches the given structured fields to every
+	// subsequent call, instead of having callers stuff correlation/trace IDs into Message.
+	WithFields(fields map[string]interface{}) Logger
+	// AddHook registers a Hook to be fired for every log Entry matching one of its Levels.
+	AddHook(hook Hook)
+	// SetLevel overrides the log level for a single subsystem (sql, redis, http, service) at
+	// runtime, on top of whatever LOG_LEVEL_OVERRIDES set at startup.
+	SetLevel(subsystem string, lvl Level)
+	// LevelOverridesHandler serves the current per-subsystem overrides (GET) and lets callers
+	// change them (POST). This package registers no route for it; mount it on an admin mux.
+	LevelOverridesHandler() http.Handler
+	// Close releases any resource the Logger holds (e.g. a file opened by NewFileLogger).
+	// It flushes the async log pipeline first, so no queued entry is lost.
+	Close() error
+	// Flush blocks until every entry queued before the call has been written, or ctx is done.
+	// Call it during graceful shutdown so a SIGTERM can't truncate in-flight log lines.
+	Flush(ctx context.Context) error
+	// PipelineStats reports the async log queue's backpressure metrics, so callers can wire
+	// Dropped into a HealthCheck payload or a logs_dropped_total gauge.
+	PipelineStats() PipelineStats
 }
 
 type logger struct {
@@ -32,27 +49,71 @@ type logger struct {
 	normalOut  io.Writer
 	errorOut   io.Writer
 	isTerminal bool
+	formatter  Formatter
+	hooks      *hookRegistry
+	fields     map[string]interface{}
+
+	// queueSize, overflowPolicy and gracefulShutdown are read once, by newPipeline, during
+	// construction; set them via WithQueueSize/WithOverflowPolicy/WithGracefulShutdown before the
+	// Logger is built, not afterwards.
+	queueSize        int
+	overflowPolicy   OverflowPolicy
+	gracefulShutdown bool
+	pipe             *pipeline
+	levels           *levelOverrides
 }
 
-type logEntry struct {
-	Level   Level       `json:"Level"`
-	Time    time.Time   `json:"time"`
-	Message interface{} `json:"message"`
+// Option configures optional behavior when building a Logger with NewLogger or NewFileLogger.
+type Option func(*logger)
+
+// WithQueueSize overrides the async log pipeline's buffer size (default defaultQueueSize).
+func WithQueueSize(size int) Option {
+	return func(l *logger) { l.queueSize = size }
 }
 
-func (l *logger) logf(level Level, format string, args ...interface{}) {
-	if level < l.level {
-		return
-	}
+// WithOverflowPolicy overrides what happens once the async log pipeline's queue is full
+// (default Block).
+func WithOverflowPolicy(p OverflowPolicy) Option {
+	return func(l *logger) { l.overflowPolicy = p }
+}
 
-	out := l.normalOut
-	if level >= ERROR {
-		out = l.errorOut
+// WithGracefulShutdown controls whether this Logger installs its own SIGTERM/SIGINT handler to
+// flush the async log pipeline on shutdown (default enabled). Disable it if the application
+// already owns SIGTERM/SIGINT for its own graceful shutdown and calls Flush/Close itself, so the
+// two handlers don't race to react to the same signal.
+func WithGracefulShutdown(enabled bool) Option {
+	return func(l *logger) { l.gracefulShutdown = enabled }
+}
+
+// Entry is a single log record. Formatters render it and Hooks observe it.
+type Entry struct {
+	Level   Level                  `json:"Level"`
+	Time    time.Time              `json:"time"`
+	Message interface{}            `json:"message"`
+	Fields  map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON serializes Fields at the top level, alongside Level, time and message, rather
+// than nesting them, so structured context is queryable directly in log-aggregator dashboards.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(e.Fields)+3)
+
+	for k, v := range e.Fields {
+		m[k] = v
 	}
 
-	entry := logEntry{
-		Level: level,
-		Time:  time.Now(),
+	m["Level"] = e.Level
+	m["time"] = e.Time
+	m["message"] = e.Message
+
+	return json.Marshal(m)
+}
+
+func (l *logger) logf(level Level, format string, args ...interface{}) {
+	entry := Entry{
+		Level:  level,
+		Time:   time.Now(),
+		Fields: l.fields,
 	}
 
 	switch {
@@ -64,11 +125,33 @@ func (l *logger) logf(level Level, format string, args ...interface{}) {
 		entry.Message = fmt.Sprintf(format+"", args...) // TODO - this is stupid. We should not need empty string.
 	}
 
-	if l.isTerminal {
-		l.prettyPrint(entry, out)
-	} else {
-		_ = json.NewEncoder(out).Encode(entry)
+	if level < l.thresholdFor(entry.Message) {
+		return
+	}
+
+	out := l.normalOut
+	if level >= ERROR {
+		out = l.errorOut
+	}
+
+	l.pipe.enqueue(entry, out)
+}
+
+func (l *logger) WithFields(fields map[string]interface{}) Logger {
+	merged := make(map[string]interface{}, len(l.fields)+len(fields))
+
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+
+	for k, v := range fields {
+		merged[k] = v
 	}
+
+	clone := *l
+	clone.fields = merged
+
+	return &clone
 }
 
 func (l *logger) Debug(args ...interface{}) {
@@ -103,62 +186,35 @@ func (l *logger) Errorf(format string, args ...interface{}) {
 	l.logf(ERROR, format, args...)
 }
 
-func (l *logger) prettyPrint(e logEntry, out io.Writer) {
-	// Giving special treatment to framework's request logs in terminal display. This does not add any overhead
-	// in running the server.
-	switch msg := e.Message.(type) {
-	case middleware.RequestLog:
-		fmt.Fprintf(out, "\u001B[38;5;%dm%s\u001B[0m [%s] \u001B[38;5;8m%s \u001B[38;5;%dm%d\u001B[0m "+
-			"%8d\u001B[38;5;8mµs\u001B[0m %s %s \n", e.Level.color(), e.Level.String()[0:4],
-			e.Time.Format("15:04:05"), msg.ID, colorForStatusCode(msg.Response), msg.Response, msg.ResponseTime, msg.Method, msg.URI)
-	case sql.Log:
-		fmt.Fprintf(out, "\u001B[38;5;%dm%s\u001B[0m [%s] \u001B[38;5;8m%-32s \u001B[38;5;24m%s\u001B[0m %8d\u001B[38;5;8mµs\u001B[0m   %v\n",
-			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), msg.Type, "SQL", msg.Duration, msg.Query)
-	case redis.QueryLog:
-		l.printRedisQueryLog(e, msg, out)
-	case service.Log:
-		fmt.Fprintf(out, "\u001B[38;5;%dm%s\u001B[0m [%s] \u001B[38;5;8m%s \u001B[38;5;%dm%d\u001B[0m %8d\u001B[38;5;8mµs\u001B[0m %s %s \n",
-			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), msg.CorrelationID, colorForStatusCode(msg.ResponseCode),
-			msg.ResponseCode, msg.ResponseTime, msg.HTTPMethod, msg.URI)
-	case service.ErrorLog:
-		fmt.Fprintf(out, "\u001B[38;5;%dm%s\u001B[0m [%s] \u001B[38;5;8m%s "+
-			"\u001B[38;5;%dm%d\u001B[0m %8d\u001B[38;5;8mµs\u001B[0m %s %s \033[0;31m %s \n",
-			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), msg.CorrelationID, colorForStatusCode(msg.ResponseCode),
-			msg.ResponseCode, msg.ResponseTime, msg.HTTPMethod, msg.URI, msg.ErrorMessage)
-	default:
-		fmt.Fprintf(out, "\u001B[38;5;%dm%s\u001B[0m [%s] %v\n", e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), e.Message)
+// NewLogger creates a Logger writing to stdout/stderr. The formatter defaults to PrettyFormatter
+// on an interactive terminal and JSONFormatter otherwise, both overridable via LOG_FORMATTER.
+// Logging happens off a buffered, background-drained queue; WithQueueSize/WithOverflowPolicy
+// tune its size and backpressure behavior.
+func NewLogger(level Level, opts ...Option) Logger {
+	l := &logger{
+		normalOut:        os.Stdout,
+		errorOut:         os.Stderr,
+		hooks:            newHookRegistry(),
+		gracefulShutdown: true,
 	}
-}
 
-// colorForStatusCode provide color for the status code in the terminal when logs is being pretty-printed.
-func colorForStatusCode(status int) int {
-	const (
-		blue   = 34
-		red    = 202
-		yellow = 220
-	)
+	l.level = level
 
-	switch {
-	case status >= 200 && status < 300:
-		return blue
-	case status >= 400 && status < 500:
-		return yellow
-	case status >= 500 && status < 600:
-		return red
+	for _, opt := range opts {
+		opt(l)
 	}
 
-	return 0
-}
+	l.isTerminal = checkIfTerminal(l.normalOut)
 
-func NewLogger(level Level) Logger {
-	l := &logger{
-		normalOut: os.Stdout,
-		errorOut:  os.Stderr,
-	}
+	colorCapable := l.isTerminal
 
-	l.level = level
+	if f, ok := l.normalOut.(*os.File); ok && l.isTerminal {
+		colorCapable = enableVirtualTerminal(f)
+	}
 
-	l.isTerminal = checkIfTerminal(l.normalOut)
+	l.formatter = defaultFormatter(l.isTerminal, colorCapable)
+	l.levels = newLevelOverrides()
+	l.pipe = newPipeline(l.queueSize, l.overflowPolicy, l.gracefulShutdown, l)
 
 	return l
 }
@@ -166,10 +222,16 @@ func NewLogger(level Level) Logger {
 // TODO - Do we need this? Only used for CMD log silencing.
 func NewSilentLogger() Logger {
 	l := &logger{
-		normalOut: io.Discard,
-		errorOut:  io.Discard,
+		normalOut:        io.Discard,
+		errorOut:         io.Discard,
+		formatter:        JSONFormatter{},
+		levels:           newLevelOverrides(),
+		hooks:            newHookRegistry(),
+		gracefulShutdown: true,
 	}
 
+	l.pipe = newPipeline(l.queueSize, l.overflowPolicy, l.gracefulShutdown, l)
+
 	return l
 }
 
@@ -182,22 +244,15 @@ func checkIfTerminal(w io.Writer) bool {
 	}
 }
 
-// printRedisQueryLog formats and prints the log entry for Redis queries.
-func (l *logger) printRedisQueryLog(e logEntry, msg redis.QueryLog, out io.Writer) {
-	args := msg.Args.([]interface{})
-	strArgs := make([]string, 0, len(args))
-
-	for _, arg := range args {
-		strArgs = append(strArgs, fmt.Sprint(arg))
+// defaultFormatter picks PrettyFormatter/JSONFormatter based on terminal-ness, then lets
+// LOG_FORMATTER (json, text, logfmt, pretty) override that choice. colorCapable reports whether
+// the output is a terminal that can render ANSI escapes (on Windows, whether virtual terminal mode
+// could be enabled); shouldColorize then applies NO_COLOR/FORCE_COLOR/LOG_COLOR on top of it.
+func defaultFormatter(isTerminal, colorCapable bool) Formatter {
+	var f Formatter = JSONFormatter{}
+	if isTerminal {
+		f = PrettyFormatter{Color: shouldColorize(colorCapable)}
 	}
 
-	// Formatting and printing the log entry based on the Redis query type.
-	switch msg.Query {
-	case "pipeline":
-		fmt.Fprintf(out, "\u001B[38;5;%dm%s\u001B[0m [%s] \u001B[38;5;8m%-32s \u001B[38;5;24m%s\u001B[0m %8d\u001B[38;5;8mµs\u001B[0m %s\n",
-			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), msg.Query, "REDIS", msg.Duration, strArgs[0][1:len(strArgs[0])-1])
-	default:
-		fmt.Fprintf(out, "\u001B[38;5;%dm%s\u001B[0m [%s] \u001B[38;5;8m%-32s \u001B[38;5;24m%s\u001B[0m %8d\u001B[38;5;8mµs\u001B[0m %v\n",
-			e.Level.color(), e.Level.String()[0:4], e.Time.Format("15:04:05"), strArgs[0], "REDIS", msg.Duration, strings.Join(strArgs, " "))
-	}
+	return formatterFromEnv(f, colorCapable)
 }
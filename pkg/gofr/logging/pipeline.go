@@ -0,0 +1,211 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// shutdownFlushTimeout bounds how long the SIGTERM/SIGINT handler waits for the queue to drain
+// before giving up, so a stalled sink can't hang process shutdown forever.
+const shutdownFlushTimeout = 5 * time.Second
+
+// OverflowPolicy controls what the async log pipeline does once its queue is full.
+type OverflowPolicy int
+
+const (
+	// Block makes the caller wait until the drain goroutine frees up room in the queue. This never
+	// drops a log line, at the cost of putting the caller's goroutine back on the hook for the
+	// sink's latency under sustained overload -- the default, to keep today's behavior unsurprising.
+	Block OverflowPolicy = iota
+	// DropOldest discards the longest-queued entry to make room for the incoming one.
+	DropOldest
+	// DropNewest discards the incoming entry, keeping everything already queued.
+	DropNewest
+	// Sample keeps roughly one in sampleRate entries once the queue is full, trading completeness
+	// for a thinned-out-but-nonempty stream instead of either total silence or unbounded blocking.
+	Sample
+)
+
+const (
+	defaultQueueSize = 1024
+	sampleRate       = 10
+)
+
+// queuedEntry pairs an Entry with the writer it should ultimately be formatted onto, since normal
+// and error output may be different writers.
+type queuedEntry struct {
+	entry Entry
+	out   io.Writer
+}
+
+// pipeline holds the async log queue's state. It is shared (by pointer) across every Logger
+// derived from the same NewLogger/NewFileLogger call via WithFields, so they drain into one
+// goroutine and report one set of drop counters.
+//
+// queue is only ever closed once, by flushOnce, and only after wg confirms no producer is still
+// inside enqueue -- closing it out from under a concurrent send would panic. closing is closed
+// first so producers that haven't started yet drop their entry instead of racing the close.
+type pipeline struct {
+	queue    chan queuedEntry
+	overflow OverflowPolicy
+	dropped  uint64
+	done     chan struct{}
+
+	closing   chan struct{}
+	wg        sync.WaitGroup
+	flushOnce sync.Once
+}
+
+// newPipeline allocates the queue and starts its drain goroutine. queueSize <= 0 falls back to
+// defaultQueueSize. watchShutdown controls whether this pipeline also installs the SIGTERM/SIGINT
+// handler described on watchShutdownSignal; callers that already own process shutdown signals
+// (via WithGracefulShutdown(false)) skip it and are expected to call Flush themselves.
+func newPipeline(queueSize int, policy OverflowPolicy, watchShutdown bool, l *logger) *pipeline {
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+
+	p := &pipeline{
+		queue:    make(chan queuedEntry, queueSize),
+		overflow: policy,
+		done:     make(chan struct{}),
+		closing:  make(chan struct{}),
+	}
+
+	go p.drain(l)
+
+	if watchShutdown {
+		go p.watchShutdownSignal(l)
+	}
+
+	return p
+}
+
+// watchShutdownSignal flushes the pipeline on SIGTERM/SIGINT, so a graceful shutdown doesn't
+// truncate log lines still sitting in the queue -- the "hook it into graceful shutdown" half of
+// Flush. It exits once the pipeline is flushed, whether that happened here or via an explicit
+// Flush/Close call elsewhere.
+func (p *pipeline) watchShutdownSignal(l *logger) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+
+	defer signal.Stop(sigCh)
+
+	select {
+	case <-sigCh:
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownFlushTimeout)
+		defer cancel()
+
+		_ = l.Flush(ctx)
+	case <-p.done:
+	}
+}
+
+// enqueue applies the configured OverflowPolicy when the queue is already full. It is a no-op,
+// other than counting a drop, once Flush has started shutting the pipeline down.
+func (p *pipeline) enqueue(e Entry, out io.Writer) {
+	p.wg.Add(1)
+	defer p.wg.Done()
+
+	select {
+	case <-p.closing:
+		atomic.AddUint64(&p.dropped, 1)
+		return
+	default:
+	}
+
+	qe := queuedEntry{entry: e, out: out}
+
+	select {
+	case p.queue <- qe:
+		return
+	default:
+	}
+
+	switch p.overflow {
+	case Block:
+		p.queue <- qe
+	case DropNewest:
+		atomic.AddUint64(&p.dropped, 1)
+	case DropOldest:
+		select {
+		case <-p.queue:
+			atomic.AddUint64(&p.dropped, 1)
+		default:
+		}
+
+		select {
+		case p.queue <- qe:
+		default:
+			atomic.AddUint64(&p.dropped, 1)
+		}
+	case Sample:
+		if atomic.AddUint64(&p.dropped, 1)%sampleRate == 0 {
+			select {
+			case p.queue <- qe:
+			default:
+			}
+		}
+	}
+}
+
+// drain is the background goroutine: it fires hooks and formats every queued Entry, in order,
+// until the queue is closed by Flush.
+func (p *pipeline) drain(l *logger) {
+	for qe := range p.queue {
+		l.fireHooks(qe.entry)
+		l.formatter.Format(qe.entry, qe.out)
+	}
+
+	close(p.done)
+}
+
+// Flush blocks until every entry queued before the call has been formatted and written, or ctx is
+// done first. Call it during graceful shutdown so a SIGTERM can't truncate in-flight log lines.
+//
+// It is safe to call more than once, and safe to call concurrently with ongoing Debug/Info/Error
+// calls on this Logger or any WithFields clone sharing its pipeline: the queue is only closed
+// after every in-flight enqueue has returned, and later log calls simply get dropped (counted in
+// PipelineStats.Dropped) instead of panicking on a closed channel.
+func (l *logger) Flush(ctx context.Context) error {
+	p := l.pipe
+
+	p.flushOnce.Do(func() {
+		close(p.closing)
+
+		go func() {
+			p.wg.Wait()
+			close(p.queue)
+		}()
+	})
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PipelineStats reports the async log queue's backpressure metrics, in the same spirit as
+// datasource HealthCheck payloads: operators can poll QueueLength/Dropped, or wire Dropped into a
+// logs_dropped_total gauge, to see when a sink is stalling before it becomes an outage.
+type PipelineStats struct {
+	QueueCapacity int
+	QueueLength   int
+	Dropped       uint64
+}
+
+func (l *logger) PipelineStats() PipelineStats {
+	return PipelineStats{
+		QueueCapacity: cap(l.pipe.queue),
+		QueueLength:   len(l.pipe.queue),
+		Dropped:       atomic.LoadUint64(&l.pipe.dropped),
+	}
+}
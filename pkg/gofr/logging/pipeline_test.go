@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// captureFormatter records every Entry it's asked to format, one line per Entry's Message.
+type captureFormatter struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (c *captureFormatter) Format(e Entry, out io.Writer) {
+	c.mu.Lock()
+	c.msgs = append(c.msgs, fmt.Sprint(e.Message))
+	c.mu.Unlock()
+
+	_, _ = out.Write([]byte(fmt.Sprint(e.Message)))
+}
+
+func newTestLogger(queueSize int, policy OverflowPolicy) (*logger, *captureFormatter) {
+	cf := &captureFormatter{}
+
+	l := &logger{
+		level:     DEBUG,
+		normalOut: &bytes.Buffer{},
+		errorOut:  &bytes.Buffer{},
+		formatter: cf,
+		hooks:     newHookRegistry(),
+		levels:    newLevelOverrides(),
+	}
+
+	l.pipe = newPipeline(queueSize, policy, false, l)
+
+	return l, cf
+}
+
+func TestPipeline_BlockDeliversEverything(t *testing.T) {
+	l, cf := newTestLogger(1, Block)
+	defer func() { _ = l.Flush(context.Background()) }()
+
+	const n = 50
+
+	for i := 0; i < n; i++ {
+		l.Info(i)
+	}
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	cf.mu.Lock()
+	got := len(cf.msgs)
+	cf.mu.Unlock()
+
+	if got != n {
+		t.Errorf("Block policy delivered %d entries, want %d", got, n)
+	}
+}
+
+func TestPipeline_DropNewestCounts(t *testing.T) {
+	l, _ := newTestLogger(1, DropNewest)
+	defer func() { _ = l.Flush(context.Background()) }()
+
+	for i := 0; i < 100; i++ {
+		l.Info(i)
+	}
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if l.PipelineStats().Dropped == 0 {
+		t.Error("DropNewest policy under load should have dropped at least one entry")
+	}
+}
+
+func TestPipeline_FlushIsIdempotentAndSafeAfterward(t *testing.T) {
+	l, _ := newTestLogger(8, Block)
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("first Flush: %v", err)
+	}
+
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("second Flush should not error or panic: %v", err)
+	}
+
+	// A log call after Flush must not panic, even though the queue is closed.
+	l.Info("after flush")
+}
+
+func TestPipeline_FlushRespectsContextDeadline(t *testing.T) {
+	l, _ := newTestLogger(1, Block)
+
+	// Log one entry and let the 1ns deadline lapse, then ask Flush with the
+	// already-expired context: it must return ctx.Err() rather than hang.
+	l.Info("queued")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+
+	time.Sleep(time.Millisecond)
+
+	if err := l.Flush(ctx); err == nil {
+		t.Error("Flush with an expired context should return an error")
+	}
+}
+
+func TestPipeline_ConcurrentLogsDuringFlushDoNotPanic(t *testing.T) {
+	l, _ := newTestLogger(4, Block)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			l.Info(i)
+		}(i)
+	}
+
+	_ = l.Flush(context.Background())
+
+	wg.Wait()
+}